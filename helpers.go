@@ -7,26 +7,26 @@ import (
 	"github.com/muesli/termenv"
 )
 
-// FgColor returns a formatter that sets the foreground color.
+// FgColor returns a formatter that sets the foreground color, down-converted
+// to DefaultProfile's color space (see Profile.FgColor).
 // Example:
 //
 //	FgColor(termenv.RGBColor("#ff0000"))
 //	FgColor(termenv.ANSI256Color(196))
 //	FgColor(termenv.ANSIColor(31))
 func FgColor(c termenv.Color) Formatter {
-	seq := c.Sequence(false)
-	return CSI(seq)
+	return DefaultProfile.FgColor(c)
 }
 
-// BgColor returns a formatter that sets the background color.
+// BgColor returns a formatter that sets the background color, down-converted
+// to DefaultProfile's color space (see Profile.BgColor).
 // Example:
 //
 //	BgColor(termenv.RGBColor("#ff0000"))
 //	BgColor(termenv.ANSI256Color(196))
 //	BgColor(termenv.ANSIColor(31))
 func BgColor(c termenv.Color) Formatter {
-	seq := c.Sequence(true)
-	return CSI(seq)
+	return DefaultProfile.BgColor(c)
 }
 
 // CSI wraps the text in the given CSI (Control Sequence Introducer) sequence.
@@ -74,36 +74,60 @@ func XPad(left, right int) Formatter {
 	})
 }
 
-// LineWrap wraps the text at the given width.
-// It breaks lines at word boundaries when possible. It will never break up
-// a word so that URLs and other long strings present correctly.
+// LineWrap wraps the text at the given width, measured in terminal display
+// columns rather than bytes (see DisplayWidth), so multi-byte runes, wide
+// East Asian characters, and ANSI CSI sequences (e.g. from FgColor) are
+// accounted for correctly. It breaks lines at word boundaries when
+// possible, and never splits a grapheme cluster. It will never break up a
+// word so that URLs and other long strings present correctly.
 func LineWrap(width int) Formatter {
-	return formatterFunc(func(t *Text) {
-		var col int
+	return lineWrapFormatter{width: width}
+}
 
-		for at := t.Head(); at != nil; at = at.Next {
-			nlAt := strings.IndexByte(at.S, '\n')
-			if nlAt < 0 {
-				nlAt = len(at.S)
-			}
-			col += nlAt
+type lineWrapFormatter struct{ width int }
 
-			overflow := (width - col) * -1
-			if overflow <= 0 {
-				continue
-			}
+func (l lineWrapFormatter) Format(t *Text) {
+	col := 0
+
+	for at := t.Head(); at != nil; at = at.Next {
+		nlAt := strings.IndexByte(at.S, '\n')
+		if nlAt < 0 {
+			nlAt = len(at.S)
+		}
+		nlCol := stringWidth(at.S[:nlAt])
+		col += nlCol
+
+		overflow := col - l.width
+		if overflow <= 0 {
+			continue
+		}
+
+		limit := widthToByte(at.S[:nlAt], nlCol-overflow+1)
+		spaceAt := strings.LastIndexFunc(at.S[:limit], unicode.IsSpace)
+		if spaceAt < 0 {
+			// Never break up a word.
+			continue
+		}
 
-			spaceAt := strings.LastIndexFunc(at.S[:nlAt-overflow+1], unicode.IsSpace)
-			if spaceAt < 0 {
-				// Never break up a word.
+		next := at.Split(spaceAt)
+		at.S = strings.TrimRight(at.S, " \t")
+		next.S = strings.TrimLeft(next.S, " \t")
+		next.Insert("\n")
+		col = 0
+	}
+}
+
+// Indent indents every line of text by n spaces.
+func Indent(n int) Formatter {
+	pad := strings.Repeat(" ", n)
+	return formatterFunc(func(t *Text) {
+		t.Prepend(pad)
+		for at := t.Head(); at != nil; at = at.Next {
+			if at.Next == nil && strings.HasSuffix(at.S, "\n") {
+				at.S = strings.ReplaceAll(at.S[:len(at.S)-1], "\n", "\n"+pad) + "\n"
 				continue
 			}
-
-			next := at.Split(spaceAt)
-			at.S = strings.TrimRight(at.S, " \t")
-			next.S = strings.TrimLeft(next.S, " \t")
-			next.Insert("\n")
-			col = 0
+			at.S = strings.ReplaceAll(at.S, "\n", "\n"+pad)
 		}
 	})
 }
@@ -0,0 +1,50 @@
+package pretty
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// oscStart and oscEnd are the OSC (Operating System Command) sequence
+// introducer, ESC ']', and string terminator (ST), ESC '\'.
+const (
+	oscStart = "\x1b]"
+	oscEnd   = "\x1b\\"
+)
+
+func oscSeq(params ...string) string {
+	return oscStart + strings.Join(params, ";") + oscEnd
+}
+
+// OSC wraps the text in the given OSC (Operating System Command) sequence,
+// closing it with an empty-params OSC sequence. It is the OSC analogue of
+// CSI.
+func OSC(params ...string) Formatter {
+	if len(params) == 0 {
+		return Nop
+	}
+	return Wrap(oscSeq(params...), oscSeq())
+}
+
+// Hyperlink returns a Formatter that wraps the text in an OSC 8 hyperlink
+// sequence to url, down-converted through DefaultProfile (see
+// Profile.Hyperlink) so that non-TTY destinations get "text (url)" instead.
+func Hyperlink(url string) Formatter {
+	return DefaultProfile.Hyperlink(url)
+}
+
+// Hyperlink returns a Formatter that wraps the text in an OSC 8 hyperlink
+// (see
+// https://gist.github.com/egmontkob/eb114294efbcd5adb1944c9f3cb5feda) so
+// that terminals which support it render the text as a clickable link to
+// url, while others just show the text. For the Ascii profile, the link is
+// stripped down to "text (url)" instead.
+func (p Profile) Hyperlink(url string) Formatter {
+	if p.p == termenv.Ascii {
+		return formatterFunc(func(t *Text) {
+			t.Append(" (" + url + ")")
+		})
+	}
+	return Wrap(oscSeq("8", "", url), oscSeq("8", "", ""))
+}
@@ -0,0 +1,33 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSC(t *testing.T) {
+	txt := String("title")
+	OSC("0", "my title").Format(txt)
+	require.Equal(t, "\x1b]0;my title\x1b\\title\x1b]\x1b\\", txt.String())
+}
+
+func TestOSCEmptyIsNop(t *testing.T) {
+	txt := String("a")
+	OSC().Format(txt)
+	requireText(t, txt, "a")
+}
+
+func TestHyperlink(t *testing.T) {
+	txt := String("docs")
+	TrueColor.Hyperlink("https://example.com").Format(txt)
+	require.Contains(t, txt.String(), "docs")
+	require.Contains(t, txt.String(), "https://example.com")
+	require.Equal(t, 4, DisplayWidth(txt)) // OSC 8 counts as zero width
+}
+
+func TestHyperlinkAsciiProfile(t *testing.T) {
+	txt := String("docs")
+	Ascii.Hyperlink("https://example.com").Format(txt)
+	requireText(t, txt, "docs (https://example.com)")
+}
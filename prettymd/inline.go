@@ -0,0 +1,93 @@
+package prettymd
+
+import "strings"
+
+// parseInline scans a line of text for **strong**, *em*, `code`, and
+// [text](url) spans, emitting opText interleaved with the matching
+// start/end ops. Unmatched delimiters are treated as literal text.
+func parseInline(text string) []op {
+	var ops []op
+	var buf strings.Builder
+
+	flush := func() {
+		if buf.Len() > 0 {
+			ops = append(ops, op{kind: opText, text: buf.String()})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		if text[i] == '[' {
+			if label, url, n := parseLink(text[i:]); n > 0 {
+				flush()
+				ops = append(ops, op{kind: opLinkStart, url: url})
+				ops = append(ops, parseInline(label)...)
+				ops = append(ops, op{kind: opLinkEnd})
+				i += n
+				continue
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(text[i:], "**"):
+			end := strings.Index(text[i+2:], "**")
+			if end < 0 {
+				// No closing "**": treat the pair as literal text rather
+				// than falling through to the single-"*" case below,
+				// which would otherwise match the second "*" as a
+				// self-closing, empty emphasis span.
+				buf.WriteByte(text[i])
+				buf.WriteByte(text[i+1])
+				i += 2
+				continue
+			}
+			flush()
+			ops = append(ops, op{kind: opStrongStart})
+			ops = append(ops, parseInline(text[i+2:i+2+end])...)
+			ops = append(ops, op{kind: opStrongEnd})
+			i += 2 + end + 2
+
+		case text[i] == '*' && strings.IndexByte(text[i+1:], '*') >= 0:
+			end := strings.IndexByte(text[i+1:], '*')
+			flush()
+			ops = append(ops, op{kind: opEmphasisStart})
+			ops = append(ops, parseInline(text[i+1:i+1+end])...)
+			ops = append(ops, op{kind: opEmphasisEnd})
+			i += 1 + end + 1
+
+		case text[i] == '`' && strings.IndexByte(text[i+1:], '`') >= 0:
+			end := strings.IndexByte(text[i+1:], '`')
+			flush()
+			ops = append(ops, op{kind: opCodeSpanStart})
+			ops = append(ops, op{kind: opText, text: text[i+1 : i+1+end]})
+			ops = append(ops, op{kind: opCodeSpanEnd})
+			i += 1 + end + 1
+
+		default:
+			buf.WriteByte(text[i])
+			i++
+		}
+	}
+
+	flush()
+	return ops
+}
+
+// parseLink parses a [text](url) span at the start of s. n is the number of
+// bytes consumed, or 0 if s does not start with a well-formed span.
+func parseLink(s string) (label, url string, n int) {
+	if len(s) == 0 || s[0] != '[' {
+		return "", "", 0
+	}
+	close := strings.IndexByte(s, ']')
+	if close < 0 || close+1 >= len(s) || s[close+1] != '(' {
+		return "", "", 0
+	}
+	paren := strings.IndexByte(s[close+2:], ')')
+	if paren < 0 {
+		return "", "", 0
+	}
+	label = s[1:close]
+	url = s[close+2 : close+2+paren]
+	return label, url, close + 2 + paren + 1
+}
@@ -0,0 +1,41 @@
+package prettymd
+
+// opKind identifies the kind of a block or inline markdown operation in the
+// event stream produced by parse.
+type opKind int
+
+const (
+	opParagraphStart opKind = iota
+	opParagraphEnd
+	opHeadingStart
+	opHeadingEnd
+	opText
+	opCodeBlockStart
+	opCodeBlockEnd
+	opQuoteStart
+	opQuoteEnd
+	opListItemStart
+	opListItemEnd
+	opStrongStart
+	opStrongEnd
+	opEmphasisStart
+	opEmphasisEnd
+	opCodeSpanStart
+	opCodeSpanEnd
+	opLinkStart
+	opLinkEnd
+	opBreak
+)
+
+// op is a single entry in the event stream emitted by parse. render turns
+// each op into pretty.Text.Append/Format calls, which keeps the renderer
+// decoupled from the parser: a different event source could produce the
+// same stream and reuse render unchanged.
+type op struct {
+	kind opKind
+
+	text  string // opText, opListItemStart (bullet), opCodeBlockStart (language)
+	level int    // opHeadingStart (1-6)
+	depth int    // opQuoteStart, opListItemStart (nesting depth, 0-based)
+	url   string // opLinkStart
+}
@@ -0,0 +1,208 @@
+package prettymd
+
+import (
+	"strings"
+)
+
+// parse scans Markdown source into a stream of ops describing the block
+// and inline structure of the document. It only understands the subset of
+// Markdown that render knows how to style: headings, paragraphs, fenced
+// code blocks, blockquotes, (possibly nested) lists, and the inline forms
+// **strong**, *em*, `code`, and [text](url).
+func parse(src string) []op {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var ops []op
+	wroteBlock := false
+
+	emitBreak := func() {
+		if wroteBlock {
+			ops = append(ops, op{kind: opBreak})
+		}
+		wroteBlock = true
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case isFence(line):
+			emitBreak()
+			lang := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "`"))
+			i++
+			start := i
+			for i < len(lines) && !isFence(lines[i]) {
+				i++
+			}
+			code := strings.Join(lines[start:i], "\n")
+			if i < len(lines) {
+				i++ // consume closing fence
+			}
+			ops = append(ops,
+				op{kind: opCodeBlockStart, text: lang},
+				op{kind: opText, text: code},
+				op{kind: opCodeBlockEnd},
+			)
+
+		case headingLevel(line) > 0:
+			emitBreak()
+			level := headingLevel(line)
+			text := strings.TrimSpace(line[level:])
+			ops = append(ops, op{kind: opHeadingStart, level: level})
+			ops = append(ops, parseInline(text)...)
+			ops = append(ops, op{kind: opHeadingEnd})
+			i++
+
+		case quoteDepth(line) > 0:
+			emitBreak()
+			depth := quoteDepth(line)
+			start := i
+			for i < len(lines) && quoteDepth(lines[i]) > 0 {
+				i++
+			}
+			var content []string
+			for _, l := range lines[start:i] {
+				content = append(content, stripQuote(l, depth))
+			}
+			ops = append(ops, op{kind: opQuoteStart, depth: depth - 1})
+			ops = append(ops, parse(strings.Join(content, "\n"))...)
+			ops = append(ops, op{kind: opQuoteEnd})
+
+		case listBullet(line) != "":
+			// Consecutive items of the same list stay on adjacent lines;
+			// only blank-line-separate a list from a preceding block of a
+			// different kind.
+			if len(ops) == 0 || ops[len(ops)-1].kind != opListItemEnd {
+				emitBreak()
+			}
+			depth := indentWidth(line) / 2
+			bullet, rest := listBullet(line), listText(line)
+			i++
+			// Gather any indented continuation/nested-list lines that
+			// belong to this item, keeping their original indentation so
+			// nested items compute their own absolute depth correctly.
+			for i < len(lines) && belongsToItem(lines[i], indentWidth(line)) {
+				rest += "\n" + lines[i]
+				i++
+			}
+			ops = append(ops, op{kind: opListItemStart, text: bullet, depth: depth})
+			ops = append(ops, parseItemBody(rest)...)
+			ops = append(ops, op{kind: opListItemEnd, depth: depth})
+
+		default:
+			emitBreak()
+			start := i
+			for i < len(lines) &&
+				strings.TrimSpace(lines[i]) != "" &&
+				!isFence(lines[i]) && headingLevel(lines[i]) == 0 &&
+				quoteDepth(lines[i]) == 0 && listBullet(lines[i]) == "" {
+				i++
+			}
+			text := strings.Join(trimmed(lines[start:i]), " ")
+			ops = append(ops, op{kind: opParagraphStart})
+			ops = append(ops, parseInline(text)...)
+			ops = append(ops, op{kind: opParagraphEnd})
+		}
+	}
+
+	return ops
+}
+
+// parseItemBody parses the (possibly multi-line) body of a list item. A
+// multi-line body (continuation lines or a nested list) is recursed into
+// via parse; a single-line body is treated as inline text.
+func parseItemBody(body string) []op {
+	if strings.ContainsAny(body, "\n") {
+		return parse(body)
+	}
+	return parseInline(body)
+}
+
+func isFence(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "```")
+}
+
+// headingLevel returns the ATX heading level (1-6) of line, or 0 if line is
+// not a heading.
+func headingLevel(line string) int {
+	n := 0
+	for n < len(line) && n < 6 && line[n] == '#' {
+		n++
+	}
+	if n == 0 || n >= len(line) || line[n] != ' ' {
+		return 0
+	}
+	return n
+}
+
+// quoteDepth returns the number of nested "> " blockquote markers line
+// starts with.
+func quoteDepth(line string) int {
+	s := strings.TrimLeft(line, " ")
+	depth := 0
+	for strings.HasPrefix(s, ">") {
+		depth++
+		s = strings.TrimPrefix(s, ">")
+		s = strings.TrimPrefix(s, " ")
+	}
+	return depth
+}
+
+func stripQuote(line string, depth int) string {
+	s := strings.TrimLeft(line, " ")
+	for i := 0; i < depth; i++ {
+		s = strings.TrimPrefix(s, ">")
+		s = strings.TrimPrefix(s, " ")
+	}
+	return s
+}
+
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// listBullet returns the marker text ("-", "*", "1.", ...) if line begins a
+// list item, or "" otherwise.
+func listBullet(line string) string {
+	s := strings.TrimLeft(line, " ")
+	if s == "" {
+		return ""
+	}
+	if (s[0] == '-' || s[0] == '*' || s[0] == '+') && len(s) > 1 && s[1] == ' ' {
+		return s[:1]
+	}
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	if n > 0 && n+1 < len(s) && s[n] == '.' && s[n+1] == ' ' {
+		return s[:n+1]
+	}
+	return ""
+}
+
+func listText(line string) string {
+	bullet := listBullet(line)
+	s := strings.TrimLeft(line, " ")
+	return strings.TrimPrefix(s[len(bullet):], " ")
+}
+
+// belongsToItem reports whether line is an indented continuation of a list
+// item whose marker started at indent columns.
+func belongsToItem(line string, indent int) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	return indentWidth(line) >= indent+2
+}
+
+func trimmed(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = strings.TrimSpace(l)
+	}
+	return out
+}
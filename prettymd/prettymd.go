@@ -0,0 +1,61 @@
+// Package prettymd renders Markdown source to a styled *pretty.Text using
+// pretty.Formatters, so the result can be printed directly to a terminal.
+package prettymd
+
+import "github.com/coder/pretty"
+
+// Theme maps each Markdown element kind to the pretty.Style used to render
+// it. Heading holds one Style per level, indexed 0 for "#" through 5 for
+// "######". Width, if positive, is the column width paragraphs are
+// line-wrapped to (see pretty.LineWrap); zero disables wrapping.
+type Theme struct {
+	Heading    [6]pretty.Style
+	Paragraph  pretty.Style
+	Code       pretty.Style
+	Quote      pretty.Style
+	Strong     pretty.Style
+	Emphasis   pretty.Style
+	Link       pretty.Style
+	ListBullet pretty.Style
+	Width      int
+}
+
+// headingStyle returns the Style for the given heading level (1-6),
+// falling back to the deepest configured level.
+func (th Theme) headingStyle(level int) pretty.Style {
+	if level < 1 {
+		level = 1
+	}
+	if level > 6 {
+		level = 6
+	}
+	return th.Heading[level-1]
+}
+
+// DefaultTheme is a reasonable theme for rendering to a terminal that
+// supports ANSI styling.
+var DefaultTheme = Theme{
+	Heading: [6]pretty.Style{
+		{pretty.Bold(), pretty.Underline()},
+		{pretty.Bold()},
+		{pretty.Bold()},
+		{pretty.Bold()},
+		{pretty.Bold()},
+		{pretty.Bold()},
+	},
+	Code:       pretty.Style{pretty.Italic()},
+	Quote:      pretty.Style{pretty.Italic()},
+	Strong:     pretty.Style{pretty.Bold()},
+	Emphasis:   pretty.Style{pretty.Italic()},
+	Link:       pretty.Style{pretty.Underline()},
+	ListBullet: pretty.Style{pretty.Bold()},
+	Width:      80,
+}
+
+// Render parses src as Markdown and renders it to a *pretty.Text styled
+// according to theme. Rendering goes through an intermediate stream of
+// block/inline ops (see op), which keeps the renderer decoupled from the
+// parser.
+func Render(src string, theme Theme) *pretty.Text {
+	return render(parse(src), theme)
+}
@@ -0,0 +1,88 @@
+package prettymd
+
+import (
+	"testing"
+
+	"github.com/coder/pretty"
+	"github.com/stretchr/testify/require"
+)
+
+// plainTheme applies no styling, so tests can assert on the rendered text
+// structure without worrying about ANSI escape sequences.
+var plainTheme = Theme{Width: 0}
+
+func TestRenderParagraph(t *testing.T) {
+	t.Parallel()
+	got := Render("hello world", plainTheme).String()
+	require.Equal(t, "hello world", got)
+}
+
+func TestRenderHeading(t *testing.T) {
+	t.Parallel()
+	got := Render("## Title", plainTheme).String()
+	require.Equal(t, "Title", got)
+}
+
+func TestRenderEmphasis(t *testing.T) {
+	t.Parallel()
+	got := Render("a **bold** and *em* word", plainTheme).String()
+	require.Equal(t, "a bold and em word", got)
+}
+
+func TestRenderUnmatchedStrongMarker(t *testing.T) {
+	t.Parallel()
+	got := Render("price is **5 dollars for the item", plainTheme).String()
+	require.Equal(t, "price is **5 dollars for the item", got)
+}
+
+func TestRenderCodeSpan(t *testing.T) {
+	t.Parallel()
+	got := Render("run `go test`", plainTheme).String()
+	require.Equal(t, "run go test", got)
+}
+
+func TestRenderLink(t *testing.T) {
+	t.Parallel()
+	got := Render("see [the docs](https://example.com)", plainTheme).String()
+	require.Equal(t, "see the docs (https://example.com)", got)
+}
+
+func TestRenderCodeBlock(t *testing.T) {
+	t.Parallel()
+	got := Render("```go\nfmt.Println(1)\n```", plainTheme).String()
+	require.Equal(t, "fmt.Println(1)", got)
+}
+
+func TestRenderBlockquote(t *testing.T) {
+	t.Parallel()
+	got := Render("> quoted text", plainTheme).String()
+	require.Equal(t, "  quoted text", got)
+}
+
+func TestRenderList(t *testing.T) {
+	t.Parallel()
+	got := Render("- one\n- two", plainTheme).String()
+	require.Equal(t, "- one\n- two", got)
+}
+
+func TestRenderNestedList(t *testing.T) {
+	t.Parallel()
+	src := "- one\n  - nested\n- two"
+	got := Render(src, plainTheme).String()
+	require.Contains(t, got, "nested")
+	require.Contains(t, got, "  - nested")
+}
+
+func TestRenderBlankLineSeparation(t *testing.T) {
+	t.Parallel()
+	got := Render("first\n\nsecond", plainTheme).String()
+	require.Equal(t, "first\n\nsecond", got)
+}
+
+func TestRenderStyled(t *testing.T) {
+	t.Parallel()
+	theme := DefaultTheme
+	var txt *pretty.Text = Render("**bold**", theme)
+	require.Contains(t, txt.String(), "bold")
+	require.Greater(t, txt.Len(), len("bold"))
+}
@@ -0,0 +1,174 @@
+package prettymd
+
+import "github.com/coder/pretty"
+
+// blockKinds are the op kinds that open a block-level element. A body
+// whose first op is not one of these is rendered as a single inline run
+// (this happens for one-line list items, whose body never reaches parse's
+// block-level switch).
+var blockKinds = map[opKind]bool{
+	opParagraphStart: true,
+	opHeadingStart:   true,
+	opCodeBlockStart: true,
+	opQuoteStart:     true,
+	opListItemStart:  true,
+}
+
+// render turns an op stream into a styled *pretty.Text by applying theme's
+// Formatters and Width via Text.Append/Format calls.
+func render(ops []op, theme Theme) *pretty.Text {
+	t := pretty.String("")
+	renderBlocks(t, ops, theme)
+	return t.Head()
+}
+
+// renderBlocks appends the block-level elements described by ops to t.
+func renderBlocks(t *pretty.Text, ops []op, theme Theme) {
+	if len(ops) > 0 && !blockKinds[ops[0].kind] {
+		appendInline(t, ops, theme, nil)
+		return
+	}
+
+	wrote := false
+	for i := 0; i < len(ops); {
+		switch ops[i].kind {
+		case opBreak:
+			if wrote {
+				t.Append("\n\n")
+			}
+			i++
+
+		case opParagraphStart:
+			end := matchEnd(ops, i, opParagraphStart, opParagraphEnd)
+			seg := pretty.String("")
+			appendInline(seg, ops[i+1:end], theme, theme.Paragraph)
+			if theme.Width > 0 {
+				pretty.LineWrap(theme.Width).Format(seg)
+			}
+			t.Append(seg.Head().String())
+			i, wrote = end+1, true
+
+		case opHeadingStart:
+			level := ops[i].level
+			end := matchEnd(ops, i, opHeadingStart, opHeadingEnd)
+			seg := pretty.String("")
+			appendInline(seg, ops[i+1:end], theme, nil)
+			theme.headingStyle(level).Format(seg)
+			t.Append(seg.Head().String())
+			i, wrote = end+1, true
+
+		case opCodeBlockStart:
+			// ops[i+1] is the opText carrying the code, ops[i+2] is the End.
+			seg := pretty.String(ops[i+1].text)
+			theme.Code.Format(seg)
+			t.Append(seg.Head().String())
+			i, wrote = i+3, true
+
+		case opQuoteStart:
+			depth := ops[i].depth
+			end := matchEnd(ops, i, opQuoteStart, opQuoteEnd)
+			seg := pretty.String("")
+			renderBlocks(seg, ops[i+1:end], theme)
+			theme.Quote.Format(seg)
+			pretty.Indent(2 * (depth + 1)).Format(seg)
+			t.Append(seg.Head().String())
+			i, wrote = end+1, true
+
+		case opListItemStart:
+			// A list item immediately following the close of another (no
+			// opBreak between them, since parse keeps sibling items
+			// compact) only needs a single line break, not a blank line.
+			if i > 0 && ops[i-1].kind == opListItemEnd {
+				t.Append("\n")
+			}
+			bullet, depth := ops[i].text, ops[i].depth
+			end := matchEnd(ops, i, opListItemStart, opListItemEnd)
+			seg := pretty.String("")
+			renderBlocks(seg, ops[i+1:end], theme)
+			line := pretty.String(bullet + " ")
+			theme.ListBullet.Format(line)
+			line.Append(seg.Head().String())
+			pretty.Indent(2 * depth).Format(line)
+			t.Append(line.Head().String())
+			i, wrote = end+1, true
+
+		default:
+			// Defensive: skip unbalanced/unknown ops rather than loop.
+			i++
+		}
+	}
+}
+
+// appendInline renders a run of inline ops (opText, emphasis/strong/code
+// span/link) to t. blockStyle, if non-nil, is applied to the whole run
+// (used for paragraph text).
+func appendInline(t *pretty.Text, ops []op, theme Theme, blockStyle pretty.Style) {
+	seg := pretty.String("")
+	for i := 0; i < len(ops); {
+		switch ops[i].kind {
+		case opText:
+			seg.Append(ops[i].text)
+			i++
+
+		case opStrongStart:
+			end := matchEnd(ops, i, opStrongStart, opStrongEnd)
+			inner := pretty.String("")
+			appendInline(inner, ops[i+1:end], theme, nil)
+			theme.Strong.Format(inner)
+			seg.Append(inner.Head().String())
+			i = end + 1
+
+		case opEmphasisStart:
+			end := matchEnd(ops, i, opEmphasisStart, opEmphasisEnd)
+			inner := pretty.String("")
+			appendInline(inner, ops[i+1:end], theme, nil)
+			theme.Emphasis.Format(inner)
+			seg.Append(inner.Head().String())
+			i = end + 1
+
+		case opCodeSpanStart:
+			end := matchEnd(ops, i, opCodeSpanStart, opCodeSpanEnd)
+			inner := pretty.String("")
+			appendInline(inner, ops[i+1:end], theme, nil)
+			theme.Code.Format(inner)
+			seg.Append(inner.Head().String())
+			i = end + 1
+
+		case opLinkStart:
+			end := matchEnd(ops, i, opLinkStart, opLinkEnd)
+			inner := pretty.String("")
+			appendInline(inner, ops[i+1:end], theme, nil)
+			inner.Append(" (" + ops[i].url + ")")
+			theme.Link.Format(inner)
+			seg.Append(inner.Head().String())
+			i = end + 1
+
+		default:
+			i++
+		}
+	}
+
+	if blockStyle != nil {
+		blockStyle.Format(seg)
+	}
+	t.Append(seg.Head().String())
+}
+
+// matchEnd returns the index in ops of the close op matching the open op
+// at start, accounting for further open/close pairs of the same kind
+// nested in between (e.g. a sub-list inside a list item).
+func matchEnd(ops []op, start int, open, close opKind) int {
+	depth := 1
+	for i := start + 1; i < len(ops); i++ {
+		switch ops[i].kind {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(ops) - 1
+}
@@ -0,0 +1,94 @@
+package pretty
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// Profile wraps termenv.Profile and produces Formatters that down-convert
+// requested colors to the nearest representation the target terminal
+// actually supports, instead of unconditionally emitting 24-bit truecolor
+// sequences.
+type Profile struct {
+	p termenv.Profile
+}
+
+// TrueColor is a Profile that emits 24-bit truecolor sequences.
+var TrueColor = Profile{termenv.TrueColor}
+
+// ANSI256 is a Profile that down-converts colors to the 256-color palette.
+var ANSI256 = Profile{termenv.ANSI256}
+
+// ANSI is a Profile that down-converts colors to the 16-color palette.
+var ANSI = Profile{termenv.ANSI}
+
+// Ascii is a Profile that strips all color and CSI styling. It is used for
+// non-TTY destinations such as files and CI logs.
+var Ascii = Profile{termenv.Ascii}
+
+// DefaultProfile is the Profile used by the package-level FgColor and
+// BgColor helpers. It is initialized from AutoProfile(os.Stdout).
+var DefaultProfile = AutoProfile(os.Stdout)
+
+// AutoProfile detects the color profile to use when writing to w. NO_COLOR,
+// per https://no-color.org, always forces Ascii. Otherwise FORCE_COLOR
+// ("1", "2", or "3", for ANSI, ANSI256, or TrueColor respectively) forces
+// color even when w is not a terminal. Failing both, w is downgraded to
+// Ascii unless it is a TTY, and TTYs fall back to termenv.EnvColorProfile.
+func AutoProfile(w io.Writer) Profile {
+	if os.Getenv("NO_COLOR") != "" {
+		return Ascii
+	}
+
+	switch os.Getenv("FORCE_COLOR") {
+	case "1":
+		return ANSI
+	case "2":
+		return ANSI256
+	case "3":
+		return TrueColor
+	}
+
+	if !isTerminal(w) {
+		return Ascii
+	}
+	return Profile{termenv.EnvColorProfile()}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// FgColor returns a Formatter that sets the foreground color, down-converted
+// to p's color space. It returns Nop for the Ascii profile.
+func (p Profile) FgColor(c termenv.Color) Formatter {
+	if p.p == termenv.Ascii {
+		return Nop
+	}
+	return CSI(p.p.Convert(c).Sequence(false))
+}
+
+// BgColor returns a Formatter that sets the background color, down-converted
+// to p's color space. It returns Nop for the Ascii profile.
+func (p Profile) BgColor(c termenv.Color) Formatter {
+	if p.p == termenv.Ascii {
+		return Nop
+	}
+	return CSI(p.p.Convert(c).Sequence(true))
+}
+
+// CSI wraps the text in the given CSI sequence, as the package-level CSI
+// does, except it returns Nop for the Ascii profile.
+func (p Profile) CSI(seq string) Formatter {
+	if p.p == termenv.Ascii {
+		return Nop
+	}
+	return CSI(seq)
+}
@@ -0,0 +1,37 @@
+package pretty
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileAscii(t *testing.T) {
+	txt := String("hi")
+	Ascii.FgColor(termenv.RGBColor("#ff0000")).Format(txt)
+	requireText(t, txt, "hi")
+}
+
+func TestProfileDownConvert(t *testing.T) {
+	txt := String("hi")
+	ANSI.FgColor(termenv.RGBColor("#ff0000")).Format(txt)
+	require.Greater(t, txt.Len(), 2)
+	require.NotContains(t, txt.String(), "38;2;") // not a truecolor sequence
+}
+
+func TestAutoProfileNonTTY(t *testing.T) {
+	require.Equal(t, Ascii, AutoProfile(&bytes.Buffer{}))
+}
+
+func TestAutoProfileNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	require.Equal(t, Ascii, AutoProfile(os.Stdout))
+}
+
+func TestAutoProfileForceColor(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "3")
+	require.Equal(t, TrueColor, AutoProfile(&bytes.Buffer{}))
+}
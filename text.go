@@ -134,6 +134,14 @@ func (t *Text) Len() int {
 	}
 }
 
+// VisibleLen returns the number of terminal columns the text occupies.
+// Unlike Len, which counts raw bytes, it strips ANSI CSI escape sequences
+// and measures the remaining runes by grapheme cluster, so wide/fullwidth
+// characters and combining marks are counted correctly. See DisplayWidth.
+func (t *Text) VisibleLen() int {
+	return DisplayWidth(t)
+}
+
 // Append appends strings to the end of the text
 // in order.
 // Example:
@@ -0,0 +1,126 @@
+package pretty
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// textSpan is a contiguous run of a string that is either an ANSI escape
+// sequence (zero display width) or ordinary text to be measured by
+// grapheme cluster.
+type textSpan struct {
+	s      string
+	escape bool
+}
+
+// scanSpans splits s into alternating spans of plain text and ANSI escape
+// sequences: CSI (Control Sequence Introducer, ESC '[' ... followed by a
+// final byte in the range '@'-'~') and OSC (Operating System Command,
+// ESC ']' ... terminated by ST "ESC \" or BEL). It lets width computations
+// skip escape sequences without having to strip them from the underlying
+// string.
+func scanSpans(s string) []textSpan {
+	var spans []textSpan
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '\x1b')
+		if i < 0 {
+			spans = append(spans, textSpan{s: s})
+			break
+		}
+		if i > 0 {
+			spans = append(spans, textSpan{s: s[:i]})
+			s = s[i:]
+		}
+
+		var end int
+		switch {
+		case len(s) > 1 && s[1] == '[':
+			end = 2
+			for end < len(s) && (s[end] < '@' || s[end] > '~') {
+				end++
+			}
+			if end < len(s) {
+				end++ // consume the final byte
+			}
+
+		case len(s) > 1 && s[1] == ']':
+			end = 2
+			for end < len(s) {
+				if s[end] == '\x07' {
+					end++
+					break
+				}
+				if s[end] == '\x1b' && end+1 < len(s) && s[end+1] == '\\' {
+					end += 2
+					break
+				}
+				end++
+			}
+
+		default:
+			end = 1
+		}
+
+		spans = append(spans, textSpan{s: s[:end], escape: true})
+		s = s[end:]
+	}
+	return spans
+}
+
+// stringWidth returns the number of terminal columns s occupies, ignoring
+// CSI/OSC escape sequences and measuring the remaining text by grapheme
+// cluster so that wide/fullwidth runes count as 2 columns and zero-width
+// or combining runes count as 0.
+func stringWidth(s string) int {
+	w := 0
+	for _, span := range scanSpans(s) {
+		if !span.escape {
+			w += uniseg.StringWidth(span.s)
+		}
+	}
+	return w
+}
+
+// widthToByte returns the byte offset into s up to which the display
+// width is at most maxWidth. The offset always falls on a grapheme
+// cluster boundary, so it is safe to slice s at the returned index
+// without splitting a multi-byte rune or combining mark.
+func widthToByte(s string, maxWidth int) int {
+	if maxWidth <= 0 {
+		return 0
+	}
+
+	w, off := 0, 0
+	for _, span := range scanSpans(s) {
+		if span.escape {
+			off += len(span.s)
+			continue
+		}
+
+		g := uniseg.NewGraphemes(span.s)
+		for g.Next() {
+			start, end := g.Positions()
+			cw := uniseg.StringWidth(span.s[start:end])
+			if w+cw > maxWidth {
+				return off
+			}
+			w += cw
+			off += end - start
+		}
+	}
+	return off
+}
+
+// DisplayWidth returns the number of terminal columns the text occupies.
+// Unlike Len, it strips ANSI CSI/OSC escape sequences (such as those
+// inserted by FgColor and Hyperlink) before measuring, and accounts for
+// grapheme clusters and East Asian wide characters so that multi-byte
+// runes, combining marks, and emoji sequences are counted correctly.
+func DisplayWidth(t *Text) int {
+	w := 0
+	for at := t.Head(); at != nil; at = at.Next {
+		w += stringWidth(at.S)
+	}
+	return w
+}
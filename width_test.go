@@ -0,0 +1,80 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "ASCII",
+			input:    "hello",
+			expected: 5,
+		},
+		{
+			name:     "WideRunes",
+			input:    "你好", // two fullwidth runes
+			expected: 4,
+		},
+		{
+			name:     "CombiningMark",
+			input:    "é", // "e" + combining acute accent
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txt := String(tc.input)
+			require.Equal(t, tc.expected, DisplayWidth(txt))
+			require.Equal(t, tc.expected, txt.VisibleLen())
+		})
+	}
+
+	t.Run("CSIIgnored", func(t *testing.T) {
+		txt := String("hi")
+		TrueColor.FgColor(termenv.RGBColor("#ff0000")).Format(txt)
+		require.Equal(t, 2, DisplayWidth(txt))
+		require.Greater(t, txt.Len(), 2)
+	})
+}
+
+func TestLineWrapWidthAware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WideRunes", func(t *testing.T) {
+		txt := String("你好 你好")
+		LineWrap(4).Format(txt)
+		requireText(t, txt, "你好\n你好")
+	})
+
+	t.Run("IgnoresColorSequences", func(t *testing.T) {
+		txt := String("The crazy fox")
+		TrueColor.FgColor(termenv.RGBColor("#ff0000")).Format(txt)
+		LineWrap(9).Format(txt)
+		require.Equal(t, "The crazy\nfox", visibleOnly(txt))
+	})
+}
+
+// visibleOnly strips CSI sequences so assertions can focus on the visible
+// text regardless of the exact escape sequence emitted.
+func visibleOnly(t *Text) string {
+	var sb []byte
+	for at := t.Head(); at != nil; at = at.Next {
+		for _, span := range scanSpans(at.S) {
+			if !span.escape {
+				sb = append(sb, span.s...)
+			}
+		}
+	}
+	return string(sb)
+}
@@ -0,0 +1,89 @@
+package pretty
+
+import (
+	"bytes"
+	"io"
+)
+
+// streamWriter buffers incoming bytes line by line, applies a Formatter to
+// each line, and writes the result to an underlying writer. It implements
+// io.WriteCloser: Close flushes any trailing, newline-less data.
+type streamWriter struct {
+	w   io.Writer
+	f   Formatter
+	buf bytes.Buffer
+}
+
+// NewWriter returns an io.WriteCloser that, for each line written through
+// it, builds a *Text from the line, formats it with f, and writes the
+// result to w. This lets callers colorize output as it streams in (e.g.
+// log tailing or piping a subprocess's output) instead of buffering the
+// entire output before formatting it. Call Close to flush any data written
+// without a trailing newline.
+func NewWriter(w io.Writer, f Formatter) io.WriteCloser {
+	return &streamWriter{w: w, f: f}
+}
+
+// Write implements io.Writer.
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for {
+		nlAt := bytes.IndexByte(p, '\n')
+		if nlAt < 0 {
+			sw.buf.Write(p)
+			break
+		}
+		sw.buf.Write(p[:nlAt])
+		if err := sw.flush(true); err != nil {
+			return n, err
+		}
+		p = p[nlAt+1:]
+	}
+	return n, nil
+}
+
+// Close flushes any buffered data that was written without a trailing
+// newline. It implements io.Closer.
+func (sw *streamWriter) Close() error {
+	return sw.flush(false)
+}
+
+func (sw *streamWriter) flush(newline bool) error {
+	if sw.buf.Len() == 0 && !newline {
+		return nil
+	}
+
+	line := String(sw.buf.String())
+	sw.buf.Reset()
+
+	sw.f.Format(line)
+
+	if _, err := line.WriteTo(sw.w); err != nil {
+		return err
+	}
+	if newline {
+		if _, err := io.WriteString(sw.w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamPair holds a pair of Writers, typically for a subprocess's stdout
+// and stderr, that format independently before being multiplexed onto the
+// same underlying writer.
+type StreamPair struct {
+	Stdout io.WriteCloser
+	Stderr io.WriteCloser
+}
+
+// NewStreamPair returns a StreamPair that writes both streams to w, each
+// through its own Formatter. It covers the common case of painting a
+// subprocess's stderr a different color than its stdout while interleaving
+// both onto one destination.
+func NewStreamPair(w io.Writer, stdout, stderr Formatter) StreamPair {
+	return StreamPair{
+		Stdout: NewWriter(w, stdout),
+		Stderr: NewWriter(w, stderr),
+	}
+}
@@ -0,0 +1,72 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterLineByLine(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, XPad(1, 0))
+
+	_, err := w.Write([]byte("a\nb\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, " a\n b\n", buf.String())
+}
+
+func TestWriterFlushesOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, XPad(1, 0))
+
+	_, err := w.Write([]byte("no newline"))
+	require.NoError(t, err)
+	require.Equal(t, "", buf.String())
+
+	require.NoError(t, w.Close())
+	require.Equal(t, " no newline", buf.String())
+}
+
+func TestWriterSplitAcrossWrites(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, XPad(1, 0))
+
+	_, err := w.Write([]byte("hel"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("lo\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, " hello\n", buf.String())
+}
+
+func TestWriterLineWrapPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, LineWrap(6))
+
+	// Each write is formatted as its own independent line: "ef gh" (5
+	// columns) fits under width 6 on its own and needs no wrap, even
+	// though "abcd" left off at column 4.
+	_, err := w.Write([]byte("abcd\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ef gh\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, "abcd\nef gh\n", buf.String())
+}
+
+func TestNewStreamPair(t *testing.T) {
+	var buf bytes.Buffer
+	streams := NewStreamPair(&buf, XPad(1, 0), XPad(2, 0))
+
+	_, err := streams.Stdout.Write([]byte("out\n"))
+	require.NoError(t, err)
+	_, err = streams.Stderr.Write([]byte("err\n"))
+	require.NoError(t, err)
+
+	require.Equal(t, " out\n  err\n", buf.String())
+}